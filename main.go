@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"math/rand"
@@ -29,6 +30,35 @@ var (
 	mainStyle    = lipgloss.NewStyle()
 )
 
+// styleSet is the subset of styles Model needs to draw itself, bound to a
+// single lipgloss.Renderer. The package-level styles above are rendered
+// through lipgloss's global renderer, which is fine for a single local
+// terminal but would race and misdetect color support across concurrent SSH
+// sessions, so Model renders through its own styleSet instead.
+type styleSet struct {
+	main lipgloss.Style
+	good lipgloss.Style
+	bad  lipgloss.Style
+	help lipgloss.Style
+}
+
+// newStyleSet builds a styleSet bound to r, so every style it returns
+// negotiates color support against that renderer's own output rather than
+// the process-global one.
+func newStyleSet(r *lipgloss.Renderer) styleSet {
+	return styleSet{
+		main: r.NewStyle(),
+		good: r.NewStyle().Foreground(green),
+		bad:  r.NewStyle().Foreground(red),
+		help: r.NewStyle().Foreground(lipgloss.Color("241")),
+	}
+}
+
+// defaultStyleSet renders through lipgloss's default (process-global)
+// renderer, matching the original package-level styles, for local runs
+// outside of -serve where there's only ever one terminal to negotiate with.
+var defaultStyleSet = newStyleSet(lipgloss.DefaultRenderer())
+
 type Status int
 
 const (
@@ -37,25 +67,34 @@ const (
 	Bad       Status = 0
 	API_URL          = "https://random-word-api.herokuapp.com/word?number=10"
 	WORD_FILE        = "google-10000-english-usa-no-swears-medium.txt"
-	ok_inputs        = " abcdefghijklmnopqrstuvwxyz"
+	QUOTE_FILE       = "quotes.txt"
 )
 
 type Model struct {
-	chars    string
-	words    []string
-	inputs   []Status
-	current  int
-	quitting bool
-	time     stopwatch.Model
-	stats    string
+	chars           []rune
+	words           []string
+	inputs          []Status
+	current         int
+	quitting        bool
+	time            stopwatch.Model
+	stats           string
+	source          string
+	path            string
+	mode            Mode
+	limit           time.Duration
+	keystrokes      int
+	errorsCommitted int
+	newBestWPM      bool
+	newBestAccur    bool
+	historyDisabled bool
+	styles          styleSet
 }
 
-func NewModel(words []string) Model {
-	w, _, err := term.GetSize(0)
-	if err != nil {
-		panic("Couldn't get terminal size.")
-	}
-	s := wordwrap.String(strings.Join(words, " "), w)
+// timerExpiredMsg signals that a timed-mode countdown has reached zero.
+type timerExpiredMsg struct{}
+
+func NewModel(words []string, width int, source, path string, mode Mode, limit time.Duration) Model {
+	s := []rune(wordwrap.String(strings.Join(words, " "), width))
 	if len(s) == 0 {
 		panic("Model creation error : zero words provided")
 	}
@@ -63,46 +102,119 @@ func NewModel(words []string) Model {
 	for index := range inputs {
 		inputs[index] = Neutral
 	}
-	return Model{chars: s, words: words, inputs: inputs, quitting: false, time: stopwatch.NewWithInterval(time.Millisecond), stats: ""}
+	return Model{chars: s, words: words, inputs: inputs, quitting: false, time: stopwatch.NewWithInterval(time.Millisecond), stats: "", source: source, path: path, mode: mode, limit: limit, styles: defaultStyleSet}
 
 }
 
+// extend appends a fresh batch of words from the model's source to the end
+// of the test, for modes where the cursor should never run out of text. It
+// reports whether it grew m.chars; on failure the caller must not advance
+// m.current past the last valid index.
+func (m *Model) extend() bool {
+	words, err := wordsFor(m.source, m.path)
+	if err != nil || len(words) == 0 {
+		return false
+	}
+	m.words = append(m.words, words...)
+	addition := []rune(" " + strings.Join(words, " "))
+	m.chars = append(m.chars, addition...)
+	for range addition {
+		m.inputs = append(m.inputs, Neutral)
+	}
+	return true
+}
+
 // GetWordsFromAPI grabs 10 random words from an online JSON API at API_URL.
 // The words will most likely be long and used rarely.
-func GetWordsFromAPI() []string {
+func GetWordsFromAPI() ([]string, error) {
 	resp, err := http.Get(API_URL)
 	if err != nil {
-		panic("Error getting words")
+		return nil, fmt.Errorf("error getting words: %w", err)
 	}
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		panic("Error reading word server response")
+		return nil, fmt.Errorf("error reading word server response: %w", err)
 	}
 
 	var words_list []string
-	json.Unmarshal(body, &words_list)
-	return words_list
+	if err := json.Unmarshal(body, &words_list); err != nil {
+		return nil, fmt.Errorf("error parsing word server response: %w", err)
+	}
+	if len(words_list) == 0 {
+		return nil, fmt.Errorf("word server returned no words")
+	}
+	return words_list, nil
 }
 
-// GetWordsFromFile grabs 10 random words from the file WORD_FILE.
-// They are from the 10_000 most common American English.
+// GetWordsFromFile grabs count random words from the file at path.
+// With WORD_FILE, they are from the 10_000 most common American English.
 // Source: https://github.com/first20hours/google-10000-english/blob/d0736d492489198e4f9d650c7ab4143bc14c1e9e/google-10000-english-usa-no-swears-medium.txt
-func GetWordsFromFile() []string {
+func GetWordsFromFile(path string, count int) ([]string, error) {
 	// Open file
-	content_b, err := os.ReadFile(WORD_FILE)
+	content_b, err := os.ReadFile(path)
 	if err != nil {
-		panic("Couldn't read word file.")
+		return nil, fmt.Errorf("couldn't read word file %q: %w", path, err)
 	}
 	lines := strings.Split(string(content_b), "\n")
+	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
+		return nil, fmt.Errorf("word file %q is empty", path)
+	}
 	rand.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
-	return lines[:10]
+	if count > len(lines) {
+		count = len(lines)
+	}
+	return lines[:count], nil
+}
+
+// GetQuoteFromFile picks a random line from QUOTE_FILE and splits it into words.
+func GetQuoteFromFile() ([]string, error) {
+	content_b, err := os.ReadFile(QUOTE_FILE)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read quote file %q: %w", QUOTE_FILE, err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content_b)), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, fmt.Errorf("quote file %q is empty", QUOTE_FILE)
+	}
+	words := strings.Fields(lines[rand.Intn(len(lines))])
+	if len(words) == 0 {
+		return nil, fmt.Errorf("quote file %q contains a blank line", QUOTE_FILE)
+	}
+	return words, nil
+}
+
+// wordsFor fetches a word list from the given source: "file", "api", "quotes"
+// or "custom" (path is the picked wordlist's path, used only for "custom").
+func wordsFor(source, path string) ([]string, error) {
+	switch source {
+	case "api":
+		return GetWordsFromAPI()
+	case "file":
+		return GetWordsFromFile(WORD_FILE, 10)
+	case "quotes":
+		return GetQuoteFromFile()
+	case "custom":
+		return GetWordsFromFile(path, 10)
+	default:
+		return nil, fmt.Errorf("unknown word source %q", source)
+	}
 }
 
-// initialModel gets 10 random lowercase english words and creates a model with them
-func initialModel() Model {
-	words := GetWordsFromFile()
-	return NewModel(words)
+// modelForItem builds the Model a chosen menu entry describes, fetching its
+// words from the appropriate source. path is only used when item.custom is set.
+func modelForItem(item menuItem, source, path string, width int) (Model, error) {
+	switch {
+	case item.mode == ModeQuote:
+		source = "quotes"
+	case item.custom:
+		source = "custom"
+	}
+	words, err := wordsFor(source, path)
+	if err != nil {
+		return Model{}, err
+	}
+	return NewModel(words, width, source, path, item.mode, item.limit), nil
 }
 
 // Render prints characters in red or green according to input
@@ -111,13 +223,13 @@ func (m Model) Render() string {
 	for index, char := range m.chars {
 		var style lipgloss.Style
 		if index == m.current {
-			style = mainStyle.Underline(true)
+			style = m.styles.main.Underline(true)
 		}
 		switch m.inputs[index] {
 		case Bad:
-			style = badStyle
+			style = m.styles.bad
 		case Good:
-			style = goodStyle
+			style = m.styles.good
 		}
 		result += style.Render(string(char))
 	}
@@ -135,8 +247,8 @@ func (m *Model) Quit() {
 	typed := make([]Status, 0, len(m.words))
 	flag := Good
 	for index, curr_char := range m.chars {
-		switch string(curr_char) {
-		case " ":
+		switch curr_char {
+		case ' ':
 			// We reached the end of a word
 			typed = append(typed, flag)
 			flag = Good
@@ -157,12 +269,27 @@ func (m *Model) Quit() {
 	}
 
 	var wpm float64 = float64(length_typed_chars) / (5 * m.time.Elapsed().Minutes())
+	// accuracy reflects every keystroke committed, monkeytype-style, not just
+	// the final state of each position (so backspacing over a typo doesn't
+	// erase it from the score).
 	var accuracy float64
-	for index := range m.current {
-		accuracy += float64(m.inputs[index])
+	if m.keystrokes > 0 {
+		accuracy = float64(m.keystrokes-m.errorsCommitted) / float64(m.keystrokes)
 	}
-	accuracy = accuracy / float64(m.current)
 	m.stats = fmt.Sprintf("Correctly typed %v words in %.2fs.\nWPM: %.0f\nAccuracy: %.1f%%\nSee https://monkeytype.com/about for details about those stats.\n", length_typed_words, m.time.Elapsed().Seconds(), wpm, accuracy*100)
+
+	// Remote SSH sessions would otherwise all share, and skew, the one local
+	// history file on the host, so personal bests don't make sense there.
+	if m.historyDisabled {
+		return
+	}
+	history, err := LoadHistory()
+	if err == nil {
+		m.newBestWPM = wpm > history.BestWPM()
+		m.newBestAccur = accuracy > history.BestAccuracy()
+	}
+	run := Run{Timestamp: time.Now(), Source: m.source, WPM: wpm, Accuracy: accuracy, Duration: m.time.Elapsed()}
+	AppendRun(run)
 }
 
 func (m Model) Init() tea.Cmd {
@@ -172,27 +299,54 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
+	case timerExpiredMsg:
+		m.Quit()
+		return m, tea.Quit
 	case tea.KeyMsg:
-		key := msg.String()
-		switch key {
-		case "ctrl+c", "esc":
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
 			m.Quit()
 			return m, tea.Quit
-		default:
-			if strings.Contains(ok_inputs, key) {
+		case tea.KeyBackspace:
+			if m.current > 0 {
+				m.current--
+				m.inputs[m.current] = Neutral
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			// A fast typist's burst of keystrokes can arrive as a single
+			// KeyMsg with several runes read off the same syscall; each one
+			// is still its own keystroke and must be scored independently.
+			keys := msg.Runes
+			if msg.Type == tea.KeySpace {
+				keys = []rune{' '}
+			}
+			for _, key := range keys {
 				if m.current == 0 && !m.time.Running() {
 					cmd = m.time.Start()
+					if m.mode == ModeTimed && m.limit > 0 {
+						limit := m.limit
+						cmd = tea.Batch(cmd, tea.Tick(limit, func(time.Time) tea.Msg { return timerExpiredMsg{} }))
+					}
 				}
-				var status Status = Neutral
-				if string(m.chars[m.current]) == key {
+				m.keystrokes++
+				status := Bad
+				if m.chars[m.current] == key {
 					status = Good
 				} else {
-					status = Bad
+					m.errorsCommitted++
 				}
 				m.inputs[m.current] = status
 				if m.current == len(m.chars)-1 {
-					m.Quit()
-					return m, tea.Quit
+					if (m.mode == ModeTimed || m.mode == ModeEndless) && m.extend() {
+						m.current++
+					} else {
+						// Either a non-endless mode ran out of words, or the
+						// word source failed mid-game: either way there's no
+						// text left for m.current to advance into, so stop
+						// instead of indexing past m.chars on the next key.
+						m.Quit()
+						return m, tea.Quit
+					}
 				} else {
 					m.current++
 				}
@@ -207,14 +361,124 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m Model) View() string {
 	if m.quitting {
-		return m.Render() + "\n\n" + m.stats
+		badges := ""
+		if m.newBestWPM {
+			badges += m.styles.good.Render("new personal best WPM!") + "\n"
+		}
+		if m.newBestAccur {
+			badges += m.styles.good.Render("new personal best accuracy!") + "\n"
+		}
+		return m.Render() + "\n\n" + m.stats + badges
 	}
-	return m.Render() + "\n\n" + helpStyle.MarginLeft(2).Render(fmt.Sprintf("%.2fs · esc, ^c: exit\n", m.time.Elapsed().Seconds()))
+	return m.Render() + "\n\n" + m.styles.help.MarginLeft(2).Render(fmt.Sprintf("%.2fs · esc, ^c: exit\n", m.time.Elapsed().Seconds()))
 }
 
 func main() {
-	if _, err := tea.NewProgram(initialModel()).Run(); err != nil {
+	serve := flag.Bool("serve", false, "host the typing test over SSH instead of running locally")
+	addr := flag.String("addr", defaultServeAddr, "address to listen on when -serve is set")
+	hostKeyPath := flag.String("host-key-path", defaultHostKeyPath, "path to the SSH host key when -serve is set")
+	wordSource := flag.String("words", "file", "word source to use: file, api or quotes")
+	history := flag.Bool("history", false, "show past runs instead of starting a new one")
+	modeFlag := flag.String("mode", "", "skip the menu and start this mode directly: words, timed30, timed60, timed120, quote or endless")
+	flag.Parse()
+
+	if *serve {
+		opts := serverOptions{addr: *addr, hostKeyPath: *hostKeyPath, wordSource: *wordSource}
+		if err := runServer(opts); err != nil {
+			fmt.Println("Error running server:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *history {
+		m, err := NewHistoryModel()
+		if err != nil {
+			fmt.Println("Error loading history:", err)
+			os.Exit(1)
+		}
+		if _, err := tea.NewProgram(m).Run(); err != nil {
+			fmt.Println("Error running program:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var item menuItem
+	if *modeFlag != "" {
+		found, err := itemForFlag(*modeFlag)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		item = found
+	} else {
+		menuResult, err := tea.NewProgram(NewMenuModel()).Run()
+		if err != nil {
+			fmt.Println("Error running program:", err)
+			os.Exit(1)
+		}
+		chosen := menuResult.(MenuModel)
+		if chosen.selected == nil {
+			return
+		}
+		item = *chosen.selected
+	}
+
+	path := ""
+	if item.custom {
+		picker, err := NewPickerModel()
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		pickerResult, err := tea.NewProgram(picker).Run()
+		if err != nil {
+			fmt.Println("Error running program:", err)
+			os.Exit(1)
+		}
+		chosen := pickerResult.(PickerModel)
+		if chosen.selected == "" {
+			return
+		}
+		path, err = pickedPath(chosen.selected)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	}
+
+	w, _, err := term.GetSize(0)
+	if err != nil {
+		panic("Couldn't get terminal size.")
+	}
+	m, err := modelForItem(item, *wordSource, path, w)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if _, err := tea.NewProgram(m).Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
 }
+
+// itemForFlag resolves the -mode flag's value to the menu entry it names.
+func itemForFlag(name string) (menuItem, error) {
+	switch name {
+	case "words":
+		return menuItem{label: "Words", mode: ModeWords}, nil
+	case "timed30":
+		return menuItem{label: "Timed · 30s", mode: ModeTimed, limit: 30 * time.Second}, nil
+	case "timed60":
+		return menuItem{label: "Timed · 60s", mode: ModeTimed, limit: 60 * time.Second}, nil
+	case "timed120":
+		return menuItem{label: "Timed · 120s", mode: ModeTimed, limit: 120 * time.Second}, nil
+	case "quote":
+		return menuItem{label: "Quote", mode: ModeQuote}, nil
+	case "endless":
+		return menuItem{label: "Endless", mode: ModeEndless}, nil
+	default:
+		return menuItem{}, fmt.Errorf("unknown mode %q", name)
+	}
+}
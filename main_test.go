@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUpdateRunesBatchScoresEveryRune(t *testing.T) {
+	m := NewModel([]string{"abcd"}, 80, "file", "", ModeWords, 0)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("axc")})
+	got := updated.(Model)
+
+	if got.current != 3 {
+		t.Fatalf("current = %d, want 3 (all three runes consumed)", got.current)
+	}
+	if got.keystrokes != 3 {
+		t.Fatalf("keystrokes = %d, want 3", got.keystrokes)
+	}
+	if got.errorsCommitted != 1 {
+		t.Fatalf("errorsCommitted = %d, want 1 (only the middle rune was wrong)", got.errorsCommitted)
+	}
+	if got.inputs[0] != Good || got.inputs[1] != Bad || got.inputs[2] != Good {
+		t.Fatalf("inputs = %v, want [Good Bad Good]", got.inputs)
+	}
+}
+
+func TestUpdateBackspaceResetsInput(t *testing.T) {
+	m := NewModel([]string{"ab"}, 80, "file", "", ModeWords, 0)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	got := updated.(Model)
+
+	if got.current != 0 {
+		t.Fatalf("current = %d, want 0 after backspacing over the only typed rune", got.current)
+	}
+	if got.inputs[0] != Neutral {
+		t.Fatalf("inputs[0] = %v, want Neutral after backspace", got.inputs[0])
+	}
+}
+
+func TestUpdateCountsKeystrokesAndErrorsForAccuracy(t *testing.T) {
+	m := NewModel([]string{"ab", "cd"}, 80, "file", "", ModeWords, 0)
+	m.historyDisabled = true
+
+	// "ab cd" typed with one mistake on the space, everything else correct.
+	for _, key := range []rune("abxcd") {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		m = updated.(Model)
+	}
+
+	if m.keystrokes != 5 {
+		t.Fatalf("keystrokes = %d, want 5", m.keystrokes)
+	}
+	if m.errorsCommitted != 1 {
+		t.Fatalf("errorsCommitted = %d, want 1", m.errorsCommitted)
+	}
+
+	m.Quit()
+
+	wantAccuracy := float64(5-1) / 5
+	gotAccuracy := float64(m.keystrokes-m.errorsCommitted) / float64(m.keystrokes)
+	if gotAccuracy != wantAccuracy {
+		t.Fatalf("accuracy = %v, want %v", gotAccuracy, wantAccuracy)
+	}
+}
+
+func TestUpdateEndlessExtendFailureQuitsInsteadOfPanicking(t *testing.T) {
+	// "custom" with a path to a file that doesn't exist makes every extend()
+	// call fail, exercising the chunk0-3 regression: the model must stop
+	// instead of advancing m.current past len(m.chars).
+	m := NewModel([]string{"a"}, 80, "custom", "/no/such/wordlist.txt", ModeEndless, 0)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	got := updated.(Model)
+
+	if !got.quitting {
+		t.Fatalf("quitting = false, want true once extend() fails at the end of the text")
+	}
+	if cmd == nil {
+		t.Fatalf("cmd = nil, want tea.Quit once extend() fails")
+	}
+	if got.current >= len(got.chars) {
+		t.Fatalf("current = %d, chars has len %d: current must never be advanced past the end", got.current, len(got.chars))
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Mode selects which flavor of typing test a Model runs.
+type Mode int
+
+const (
+	ModeWords Mode = iota
+	ModeTimed
+	ModeQuote
+	ModeEndless
+)
+
+// menuItem is one selectable entry in the mode menu.
+type menuItem struct {
+	label  string
+	mode   Mode
+	limit  time.Duration
+	custom bool // words should come from a user-picked wordlist, see PickerModel
+}
+
+var menuItems = []menuItem{
+	{label: "Words", mode: ModeWords},
+	{label: "Timed · 30s", mode: ModeTimed, limit: 30 * time.Second},
+	{label: "Timed · 60s", mode: ModeTimed, limit: 60 * time.Second},
+	{label: "Timed · 120s", mode: ModeTimed, limit: 120 * time.Second},
+	{label: "Quote", mode: ModeQuote},
+	{label: "Endless", mode: ModeEndless},
+	{label: "Custom wordlist", mode: ModeWords, custom: true},
+}
+
+// MenuModel lets the user pick a game mode with the arrow keys before the
+// typing test itself starts.
+type MenuModel struct {
+	cursor   int
+	selected *menuItem
+	quitting bool
+}
+
+// NewMenuModel builds the mode-selection menu.
+func NewMenuModel() MenuModel {
+	return MenuModel{}
+}
+
+func (m MenuModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(menuItems)-1 {
+				m.cursor++
+			}
+		case "enter":
+			m.selected = &menuItems[m.cursor]
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m MenuModel) View() string {
+	if m.quitting || m.selected != nil {
+		return ""
+	}
+	result := "Pick a mode:\n\n"
+	for index, item := range menuItems {
+		cursor := "  "
+		style := mainStyle
+		if index == m.cursor {
+			cursor = "> "
+			style = keywordStyle
+		}
+		result += style.Render(cursor+item.label) + "\n"
+	}
+	return result + helpStyle.MarginLeft(2).Render("\n↑/↓: move · enter: select · esc, ^c: exit\n")
+}
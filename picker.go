@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+const listsDirName = "lists"
+
+var matchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("204")).Bold(true)
+
+// listsDir returns the directory scanned for custom wordlists, creating it
+// if necessary.
+func listsDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("couldn't find user config dir: %w", err)
+	}
+	dir = filepath.Join(dir, "typingTest", listsDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("couldn't create lists dir: %w", err)
+	}
+	return dir, nil
+}
+
+// listFiles returns the names of every .txt wordlist under listsDir.
+func listFiles() ([]string, error) {
+	dir, err := listsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read lists dir: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".txt") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// pickedPath resolves a wordlist name selected in the picker to its full path.
+func pickedPath(name string) (string, error) {
+	dir, err := listsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// PickerModel is a fuzzy-searchable picker over the custom wordlists in
+// listsDir, letting the user type to filter them by name.
+type PickerModel struct {
+	input    textinput.Model
+	names    []string
+	matches  fuzzy.Matches
+	cursor   int
+	selected string
+	quitting bool
+}
+
+// NewPickerModel scans listsDir and builds a picker around its contents.
+func NewPickerModel() (PickerModel, error) {
+	names, err := listFiles()
+	if err != nil {
+		return PickerModel{}, err
+	}
+	ti := textinput.New()
+	ti.Placeholder = "filter wordlists..."
+	ti.Focus()
+	m := PickerModel{input: ti, names: names}
+	m.matches = m.filter()
+	return m, nil
+}
+
+func (m PickerModel) filter() fuzzy.Matches {
+	if m.input.Value() == "" {
+		matches := make(fuzzy.Matches, len(m.names))
+		for index, name := range m.names {
+			matches[index] = fuzzy.Match{Str: name, Index: index}
+		}
+		return matches
+	}
+	return fuzzy.Find(m.input.Value(), m.names)
+}
+
+func (m PickerModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m PickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			if len(m.matches) > 0 {
+				m.selected = m.matches[m.cursor].Str
+			}
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.matches = m.filter()
+	if m.cursor >= len(m.matches) {
+		m.cursor = 0
+	}
+	return m, cmd
+}
+
+func (m PickerModel) View() string {
+	if m.quitting || m.selected != "" {
+		return ""
+	}
+	result := m.input.View() + "\n\n"
+	for index, match := range m.matches {
+		cursor := "  "
+		if index == m.cursor {
+			cursor = "> "
+		}
+		result += cursor + highlightMatch(match) + "\n"
+	}
+	return result + helpStyle.MarginLeft(2).Render("\n↑/↓: move · enter: select · esc, ^c: exit\n")
+}
+
+// highlightMatch renders a fuzzy match with its matched runes styled distinctly.
+func highlightMatch(match fuzzy.Match) string {
+	matched := make(map[int]bool, len(match.MatchedIndexes))
+	for _, index := range match.MatchedIndexes {
+		matched[index] = true
+	}
+	var result strings.Builder
+	for index, char := range match.Str {
+		if matched[index] {
+			result.WriteString(matchStyle.Render(string(char)))
+		} else {
+			result.WriteRune(char)
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	lm "github.com/charmbracelet/wish/logging"
+	rm "github.com/charmbracelet/wish/recover"
+)
+
+const (
+	defaultServeAddr   = ":23234"
+	defaultHostKeyPath = ".ssh/typingtest_ed25519"
+)
+
+// serverOptions holds the settings needed to host the typing test over SSH.
+type serverOptions struct {
+	addr        string
+	hostKeyPath string
+	wordSource  string
+}
+
+// runServer starts an SSH server that hands every connecting session its own
+// typing test Model, and blocks until it receives SIGINT or SIGTERM.
+func runServer(opts serverOptions) error {
+	s, err := wish.NewServer(
+		wish.WithAddress(opts.addr),
+		wish.WithHostKeyPath(opts.hostKeyPath),
+		wish.WithMiddleware(
+			bm.Middleware(teaHandler(opts)),
+			lm.Middleware(),
+			// Outermost: one session's panic (bad word source, etc.) must
+			// not take down every other connected session.
+			rm.Middleware(),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("could not create server: %w", err)
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+
+	log.Printf("starting SSH server on %s", opts.addr)
+	errs := make(chan error, 1)
+	go func() {
+		if err := s.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-done:
+	}
+
+	log.Println("stopping SSH server")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return s.Shutdown(ctx)
+}
+
+// teaHandler builds a fresh Model for every connecting session, sized to the
+// session's own PTY rather than the server's local terminal.
+func teaHandler(opts serverOptions) bm.Handler {
+	return func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+		pty, _, active := s.Pty()
+		if !active {
+			wish.Fatalln(s, "no active terminal, skipping")
+			return nil, nil
+		}
+
+		words, err := wordsFor(opts.wordSource, "")
+		if err != nil {
+			wish.Fatalln(s, err.Error())
+			return nil, nil
+		}
+		m := NewModel(words, pty.Window.Width, opts.wordSource, "", ModeWords, 0)
+		// Every session would otherwise share, and skew, the same local
+		// history file, so personal bests don't apply to public sessions.
+		m.historyDisabled = true
+		// lipgloss's default renderer is a single process-global instance:
+		// negotiating color profile against one client would apply to every
+		// other connected session too. bm.MakeRenderer gives each session
+		// its own renderer, bound to its own pty, so styling stays isolated.
+		m.styles = newStyleSet(bm.MakeRenderer(s))
+		return m, []tea.ProgramOption{tea.WithAltScreen()}
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const historyFileName = "history.json"
+
+// Run is a single finished typing test, persisted so personal bests and
+// rolling averages can be computed across sessions.
+type Run struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Source    string        `json:"source"` // file, api, quotes or custom
+	WPM       float64       `json:"wpm"`
+	Accuracy  float64       `json:"accuracy"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// History is the full set of past runs, loaded from and saved to
+// os.UserConfigDir()/typingTest/history.json.
+type History struct {
+	Runs []Run `json:"runs"`
+}
+
+// historyPath returns the path to the history file, creating its parent
+// directory if necessary.
+func historyPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("couldn't find user config dir: %w", err)
+	}
+	dir = filepath.Join(dir, "typingTest")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("couldn't create history dir: %w", err)
+	}
+	return filepath.Join(dir, historyFileName), nil
+}
+
+// LoadHistory reads the history file, returning an empty History if it
+// doesn't exist yet.
+func LoadHistory() (History, error) {
+	path, err := historyPath()
+	if err != nil {
+		return History{}, err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return History{}, nil
+	}
+	if err != nil {
+		return History{}, fmt.Errorf("couldn't read history file: %w", err)
+	}
+	var h History
+	if err := json.Unmarshal(content, &h); err != nil {
+		return History{}, fmt.Errorf("couldn't parse history file: %w", err)
+	}
+	return h, nil
+}
+
+// Save writes the history back to disk as indented JSON.
+func (h History) Save() error {
+	path, err := historyPath()
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("couldn't encode history: %w", err)
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+// BestWPM returns the highest WPM recorded, or 0 if there are no runs.
+func (h History) BestWPM() float64 {
+	var best float64
+	for _, run := range h.Runs {
+		if run.WPM > best {
+			best = run.WPM
+		}
+	}
+	return best
+}
+
+// BestAccuracy returns the highest accuracy recorded, or 0 if there are no runs.
+func (h History) BestAccuracy() float64 {
+	var best float64
+	for _, run := range h.Runs {
+		if run.Accuracy > best {
+			best = run.Accuracy
+		}
+	}
+	return best
+}
+
+// AverageWPM returns the mean WPM across all runs, or 0 if there are no runs.
+func (h History) AverageWPM() float64 {
+	if len(h.Runs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, run := range h.Runs {
+		sum += run.WPM
+	}
+	return sum / float64(len(h.Runs))
+}
+
+// AppendRun loads the history, appends run, and saves it back to disk.
+func AppendRun(run Run) (History, error) {
+	h, err := LoadHistory()
+	if err != nil {
+		return h, err
+	}
+	h.Runs = append(h.Runs, run)
+	if err := h.Save(); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+// HistoryModel is a read-only Bubble Tea view listing past runs alongside a
+// rolling average and personal bests.
+type HistoryModel struct {
+	history History
+}
+
+// NewHistoryModel loads the history file and builds a view around it.
+func NewHistoryModel() (HistoryModel, error) {
+	h, err := LoadHistory()
+	if err != nil {
+		return HistoryModel{}, err
+	}
+	return HistoryModel{history: h}, nil
+}
+
+func (m HistoryModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m HistoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m HistoryModel) View() string {
+	if len(m.history.Runs) == 0 {
+		return helpStyle.Render("No runs recorded yet.\n\nesc, q, ^c: exit\n")
+	}
+
+	result := fmt.Sprintf("Best WPM: %.0f · Best accuracy: %.1f%% · Average WPM: %.0f\n\n",
+		m.history.BestWPM(), m.history.BestAccuracy()*100, m.history.AverageWPM())
+	for _, run := range m.history.Runs {
+		result += fmt.Sprintf("%s  %-4s  %5.0f wpm  %5.1f%%\n",
+			run.Timestamp.Format("2006-01-02 15:04"), run.Source, run.WPM, run.Accuracy*100)
+	}
+	return result + helpStyle.MarginLeft(2).Render("\nesc, q, ^c: exit\n")
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestHistoryBestWPM(t *testing.T) {
+	h := History{}
+	if got := h.BestWPM(); got != 0 {
+		t.Errorf("BestWPM() on empty history = %v, want 0", got)
+	}
+
+	h.Runs = []Run{{WPM: 40}, {WPM: 72}, {WPM: 55}}
+	if got := h.BestWPM(); got != 72 {
+		t.Errorf("BestWPM() = %v, want 72", got)
+	}
+}
+
+func TestHistoryBestAccuracy(t *testing.T) {
+	h := History{}
+	if got := h.BestAccuracy(); got != 0 {
+		t.Errorf("BestAccuracy() on empty history = %v, want 0", got)
+	}
+
+	h.Runs = []Run{{Accuracy: 0.8}, {Accuracy: 0.97}, {Accuracy: 0.9}}
+	if got := h.BestAccuracy(); got != 0.97 {
+		t.Errorf("BestAccuracy() = %v, want 0.97", got)
+	}
+}
+
+func TestHistoryAverageWPM(t *testing.T) {
+	h := History{}
+	if got := h.AverageWPM(); got != 0 {
+		t.Errorf("AverageWPM() on empty history = %v, want 0", got)
+	}
+
+	h.Runs = []Run{{WPM: 40}, {WPM: 60}, {WPM: 50}}
+	if got := h.AverageWPM(); got != 50 {
+		t.Errorf("AverageWPM() = %v, want 50", got)
+	}
+}